@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/neilotoole/sq/libsq/core/errz"
+	"github.com/neilotoole/sq/libsq/core/options"
+)
+
+// OptProfile specifies the active config profile, whose options are
+// merged in ahead of source and flag options. See: resolveProfileOptions.
+var OptProfile = options.NewString(
+	"profile",
+	"",
+	'P',
+	"",
+	nil,
+	"Config profile",
+	`Specify the active config profile by name. A profile's options are
+merged in after the base config but before source and flag options. See
+"sq config profile ls".`,
+)
+
+// newConfigCmd returns the "config" command, the parent of sq's
+// config-editing subcommands.
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage sq config",
+	}
+
+	cmd.AddCommand(
+		newConfigProfileCmd(),
+	)
+
+	return cmd
+}
+
+// newConfigProfileCmd returns the "config profile" command, the parent
+// of the profile ls/use/show/diff subcommands.
+func newConfigProfileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage config profiles",
+		Long: `Manage named, inheritable sets of options ("profiles"), e.g. dev,
+prod, or ci, each of which may "extend" another profile to inherit (and
+override) its options.`,
+	}
+
+	cmd.AddCommand(
+		newConfigProfileLsCmd(),
+		newConfigProfileUseCmd(),
+		newConfigProfileShowCmd(),
+		newConfigProfileDiffCmd(),
+	)
+
+	return cmd
+}
+
+func newConfigProfileLsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ls",
+		Short: "List config profiles",
+		Args:  cobra.NoArgs,
+		RunE:  execConfigProfileLs,
+	}
+}
+
+func execConfigProfileLs(cmd *cobra.Command, _ []string) error {
+	rc := RunContextFrom(cmd.Context())
+
+	for _, name := range rc.Config.Profiles.Names() {
+		marker := "  "
+		if name == rc.Config.ActiveProfile {
+			marker = "* "
+		}
+		fmt.Fprintln(rc.Out, marker+name)
+	}
+
+	return nil
+}
+
+func newConfigProfileUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use PROFILE",
+		Short: "Set the active config profile",
+		Args:  cobra.ExactArgs(1),
+		RunE:  execConfigProfileUse,
+	}
+}
+
+func execConfigProfileUse(cmd *cobra.Command, args []string) error {
+	rc := RunContextFrom(cmd.Context())
+	name := args[0]
+
+	if _, err := rc.Config.Profiles.Flatten(name); err != nil {
+		return errz.Wrap(err, "config profile use")
+	}
+
+	rc.Config.ActiveProfile = name
+	return rc.ConfigStore.Save(rc.Config)
+}
+
+func newConfigProfileShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [PROFILE]",
+		Short: "Show a profile's flattened (inherited) options",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  execConfigProfileShow,
+	}
+}
+
+func execConfigProfileShow(cmd *cobra.Command, args []string) error {
+	rc := RunContextFrom(cmd.Context())
+
+	name := rc.Config.ActiveProfile
+	if len(args) > 0 {
+		name = args[0]
+	}
+	if name == "" {
+		return errz.Errorf("no active profile; specify PROFILE or run %q", "sq config profile use")
+	}
+
+	opts, err := rc.Config.Profiles.Flatten(name)
+	if err != nil {
+		return errz.Wrap(err, "config profile show")
+	}
+
+	keys := make([]string, 0, len(opts))
+	for key := range opts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(rc.Out, "%s = %s\n", key, opts[key])
+	}
+
+	return nil
+}
+
+func newConfigProfileDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff PROFILE1 PROFILE2",
+		Short: "Show the option differences between two profiles",
+		Args:  cobra.ExactArgs(2),
+		RunE:  execConfigProfileDiff,
+	}
+}
+
+func execConfigProfileDiff(cmd *cobra.Command, args []string) error {
+	rc := RunContextFrom(cmd.Context())
+
+	entries, err := rc.Config.Profiles.Diff(args[0], args[1])
+	if err != nil {
+		return errz.Wrap(err, "config profile diff")
+	}
+
+	for _, e := range entries {
+		fmt.Fprintf(rc.Out, "%s: %q -> %q\n", e.Key, e.Before, e.After)
+	}
+
+	return nil
+}
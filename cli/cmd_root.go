@@ -0,0 +1,19 @@
+package cli
+
+import "github.com/spf13/cobra"
+
+// newRootCmd returns sq's root command, with all top-level subcommands
+// registered.
+func newRootCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sq",
+		Short: "sq is a swiss army knife for data",
+	}
+
+	cmd.AddCommand(
+		newShellCmd(),
+		newConfigCmd(),
+	)
+
+	return cmd
+}
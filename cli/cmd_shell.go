@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/neilotoole/sq/cli/output"
+	"github.com/neilotoole/sq/cli/shell"
+	"github.com/neilotoole/sq/libsq/core/errz"
+)
+
+// newShellCmd returns the "shell" command, which drops the user into
+// an interactive REPL, analogous to the psql/mysql clients.
+func newShellCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shell",
+		Short: "Start an interactive shell",
+		Long: `Start an interactive shell (REPL) for executing SLQ queries and sq
+commands against the active source. Input accumulates until terminated
+by a semicolon (;). Lines beginning with a backslash are meta-commands,
+e.g.:
+
+  \d @handle     describe the source (or its active table)
+  \src @handle   switch the active source
+  \set key=val   set an option for the remainder of the session
+  \q             quit the shell
+
+Shell history is persisted to ~/.config/sq/history.`,
+		Args:   cobra.NoArgs,
+		Hidden: false,
+		RunE:   execShell,
+	}
+
+	return cmd
+}
+
+func execShell(cmd *cobra.Command, _ []string) error {
+	rc := RunContextFrom(cmd.Context())
+
+	opts, err := getOptionsFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	pr := output.NewPrinting()
+	if err = applyTheme(pr, opts); err != nil {
+		return err
+	}
+	pr.EnableColor(!OptMonochrome.Get(opts))
+
+	sh, err := shell.New(cmd.Root(), rc.OptionsRegistry, pr, rc.Out, rc.ErrOut)
+	if err != nil {
+		return errz.Wrap(err, "shell: init")
+	}
+	defer func() { _ = sh.Close() }()
+
+	ctx, p, err := withProgressContext(cmd, cmd.Context())
+	if err != nil {
+		return err
+	}
+	defer p.Stop()
+
+	return sh.Run(ctx)
+}
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/term"
+
+	"github.com/neilotoole/sq/libsq/core/errz"
+	"github.com/neilotoole/sq/libsq/core/options"
+	"github.com/neilotoole/sq/libsq/source"
+)
+
+// CredentialProvider resolves the password for src when its location
+// doesn't already embed one. Implementations must not log or otherwise
+// persist the returned secret.
+type CredentialProvider interface {
+	// Password returns the password for src, or an error if it could
+	// not be resolved.
+	Password(ctx context.Context, src *source.Source) (string, error)
+}
+
+// OptPasswordProvider specifies which CredentialProvider resolves a
+// source's password when its location has none and the source is marked
+// password-required. See: resolveSourcePassword.
+var OptPasswordProvider = options.NewString(
+	"driver.password.provider",
+	"",
+	0,
+	"prompt",
+	func(s string) (string, error) {
+		switch s {
+		case "prompt", "keyring", "env":
+			return s, nil
+		default:
+			return "", errz.Errorf("invalid password provider: %s", s)
+		}
+	},
+	"Password provider",
+	`How to resolve a source's password when not supplied in its location:
+one of prompt (interactive TTY prompt), keyring (OS credential store),
+or env (environment variable named after the source handle).`,
+)
+
+// OptPasswordKeyringService is the service name under which source
+// passwords are stored/retrieved when OptPasswordProvider is "keyring".
+var OptPasswordKeyringService = options.NewString(
+	"driver.password.keyring.service",
+	"",
+	0,
+	"sq",
+	nil,
+	"Keyring service name",
+	`The service name to use when storing/retrieving source passwords
+from the OS keyring (macOS Keychain, Windows Credential Manager, or
+Secret Service on Linux).`,
+)
+
+// resolveSourcePassword resolves and sets src's password via the
+// CredentialProvider configured by OptPasswordProvider, if src's
+// location requires a password but doesn't already have one embedded.
+// The resolved password is applied in-memory only; it is never written
+// back to the config. in and errOut are the RunContext's stdin/stderr
+// streams, used by the "prompt" provider instead of the process's own
+// os.Stdin/os.Stderr.
+func resolveSourcePassword(
+	ctx context.Context, opts options.Options, src *source.Source, in io.Reader, errOut io.Writer,
+) error {
+	if !src.Location.PasswordRequired() || src.Location.HasPassword() {
+		return nil
+	}
+
+	var provider CredentialProvider
+	switch OptPasswordProvider.Get(opts) {
+	case "keyring":
+		provider = &keyringCredentialProvider{service: OptPasswordKeyringService.Get(opts)}
+	case "env":
+		provider = &envCredentialProvider{}
+	default:
+		provider = &promptCredentialProvider{in: in, errOut: errOut}
+	}
+
+	pass, err := provider.Password(ctx, src)
+	if err != nil {
+		return errz.Wrap(err, fmt.Sprintf("resolve password for source %s", src.Handle))
+	}
+
+	src.Location = src.Location.WithPassword(pass)
+	return nil
+}
+
+// promptCredentialProvider resolves a password via an interactive,
+// no-echo TTY prompt, the way ssh/psql/mysql do. in and errOut are the
+// RunContext's stdin/stderr streams, not necessarily os.Stdin/os.Stderr
+// (e.g. when sq is driven from within the shell REPL or a test harness).
+type promptCredentialProvider struct {
+	in     io.Reader
+	errOut io.Writer
+}
+
+// Password implements CredentialProvider.
+func (p *promptCredentialProvider) Password(_ context.Context, src *source.Source) (string, error) {
+	f, ok := p.in.(interface{ Fd() uintptr })
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return "", errz.Errorf("source %s requires a password, but stdin is not a terminal", src.Handle)
+	}
+
+	fmt.Fprintf(p.errOut, "Password for %s: ", src.Handle)
+	b, err := term.ReadPassword(int(f.Fd()))
+	fmt.Fprintln(p.errOut)
+	if err != nil {
+		return "", errz.Err(err)
+	}
+
+	return string(b), nil
+}
+
+// keyringCredentialProvider resolves a password from the OS keyring
+// (macOS Keychain, Windows Credential Manager, Secret Service on Linux),
+// keyed by the source handle under a configurable service name.
+type keyringCredentialProvider struct {
+	service string
+}
+
+// Password implements CredentialProvider.
+func (p *keyringCredentialProvider) Password(_ context.Context, src *source.Source) (string, error) {
+	pass, err := keyring.Get(p.service, src.Handle)
+	if err != nil {
+		return "", errz.Wrap(err, fmt.Sprintf("keyring: get password for %s", src.Handle))
+	}
+
+	return pass, nil
+}
+
+// envCredentialProvider resolves a password from an environment
+// variable named after the source handle, e.g. handle "@sakila"
+// resolves SQ_PASSWORD_SAKILA.
+type envCredentialProvider struct{}
+
+// Password implements CredentialProvider.
+func (p *envCredentialProvider) Password(_ context.Context, src *source.Source) (string, error) {
+	envVar := envVarForHandle(src.Handle)
+	pass, ok := os.LookupEnv(envVar)
+	if !ok {
+		return "", errz.Errorf("env var %s not set", envVar)
+	}
+
+	return pass, nil
+}
+
+// envVarForHandle returns the environment variable name consulted by
+// envCredentialProvider for the given source handle, e.g. "@sakila"
+// becomes "SQ_PASSWORD_SAKILA".
+func envVarForHandle(handle string) string {
+	name := strings.ToUpper(strings.TrimPrefix(handle, "@"))
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	return "SQ_PASSWORD_" + name
+}
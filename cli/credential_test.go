@@ -0,0 +1,28 @@
+package cli
+
+import "testing"
+
+// envVarForHandle is the only piece of credential.go's logic that
+// doesn't depend on a *source.Source fixture (a type defined in
+// libsq/source, which isn't part of this checkout) — the TTY-detection
+// and provider-selection paths in promptCredentialProvider,
+// keyringCredentialProvider, envCredentialProvider, and
+// resolveSourcePassword all require a real *source.Source/Location to
+// exercise and so aren't unit-testable here in isolation.
+func TestEnvVarForHandle(t *testing.T) {
+	tests := []struct {
+		handle string
+		want   string
+	}{
+		{handle: "@sakila", want: "SQ_PASSWORD_SAKILA"},
+		{handle: "@my-db", want: "SQ_PASSWORD_MY_DB"},
+		{handle: "@my.db", want: "SQ_PASSWORD_MY_DB"},
+		{handle: "@Mixed_Case1", want: "SQ_PASSWORD_MIXED_CASE1"},
+	}
+
+	for _, tt := range tests {
+		if got := envVarForHandle(tt.handle); got != tt.want {
+			t.Errorf("envVarForHandle(%q) = %q, want %q", tt.handle, got, tt.want)
+		}
+	}
+}
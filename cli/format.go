@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"context"
+	"io"
+
+	"github.com/neilotoole/sq/cli/output"
+	"github.com/neilotoole/sq/cli/output/jsonlw"
+	"github.com/neilotoole/sq/cli/output/logfmtw"
+	"github.com/neilotoole/sq/libsq/core/errz"
+	"github.com/neilotoole/sq/libsq/core/options"
+)
+
+// Format is an output format, as selectable via OptFormat.
+type Format string
+
+// Supported Format values.
+const (
+	FormatJSONL  Format = "jsonl"
+	FormatLogfmt Format = "logfmt"
+)
+
+// OptFormat specifies the CLI's output format.
+var OptFormat = options.NewString(
+	"format",
+	"",
+	0,
+	FormatJSONL,
+	func(s string) (string, error) {
+		switch Format(s) {
+		case FormatJSONL, FormatLogfmt:
+			return s, nil
+		default:
+			return "", errz.Errorf("invalid format: %s", s)
+		}
+	},
+	"Output format",
+	`Specify the output format, one of: jsonl, logfmt.`,
+)
+
+// newRecordWriter returns the output.RecordWriter for format, writing
+// to out. The returned writer reports the rows it writes to the
+// *progress.Progress stored in ctx (see withProgressContext), so that
+// query-result streaming shows live progress.
+func newRecordWriter(ctx context.Context, format Format, out io.Writer, pr *output.Printing) (output.RecordWriter, error) {
+	var rw output.RecordWriter
+	switch format {
+	case FormatJSONL:
+		rw = jsonlw.NewRecordWriter(out, pr)
+	case FormatLogfmt:
+		rw = logfmtw.NewRecordWriter(out, pr)
+	default:
+		return nil, errz.Errorf("unsupported format: %s", format)
+	}
+
+	return output.NewProgressRecordWriter(ctx, rw, string(format)), nil
+}
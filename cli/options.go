@@ -68,8 +68,10 @@ func getSrcOptionsFromFlags(flags *pflag.FlagSet, reg *options.Registry,
 	return getOptionsFromFlags(flags, srcReg)
 }
 
-// getOptionsFromCmd returns the options.Options generated by merging
-// config options and flag options.
+// getOptionsFromCmd returns the options.Options generated by merging,
+// in order of increasing precedence: the base config options, the
+// active profile's options (see OptProfile and resolveProfileOptions),
+// and flag options.
 //
 // See also: getOptionsFromFlags, applySourceOptions, applyCollectionOptions.
 func getOptionsFromCmd(cmd *cobra.Command) (options.Options, error) {
@@ -86,12 +88,17 @@ func getOptionsFromCmd(cmd *cobra.Command) (options.Options, error) {
 		return nil, err
 	}
 
-	return options.Merge(configOpts, flagOpts), nil
+	profileOpts, err := resolveProfileOptions(rc, flagOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return options.Merge(configOpts, profileOpts, flagOpts), nil
 }
 
-// applySourceOptions merges options from config, src, and flags.
-// The src.Options field may be replaced or mutated. It will always
-// be non-nil (unless an error is returned).
+// applySourceOptions merges options from config, the active profile,
+// src, and flags. The src.Options field may be replaced or mutated. It
+// will always be non-nil (unless an error is returned).
 //
 // See also: getOptionsFromFlags, getOptionsFromCmd, applyCollectionOptions.
 func applySourceOptions(cmd *cobra.Command, src *source.Source) error {
@@ -107,16 +114,46 @@ func applySourceOptions(cmd *cobra.Command, src *source.Source) error {
 		return err
 	}
 
+	profileOpts, err := resolveProfileOptions(rc, flagOpts)
+	if err != nil {
+		return err
+	}
+
 	srcOpts := src.Options
 	if srcOpts == nil {
 		srcOpts = options.Options{}
 	}
 
-	effectiveOpts := options.Merge(defaultOpts, srcOpts, flagOpts)
+	effectiveOpts := options.Merge(defaultOpts, profileOpts, srcOpts, flagOpts)
 	src.Options = effectiveOpts
+
+	if err = resolveSourcePassword(cmd.Context(), effectiveOpts, src, rc.Stdin, rc.ErrOut); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// resolveProfileOptions returns the flattened options.Options of the
+// active profile: the one named by --profile (flagOpts), falling back
+// to rc.Config.ActiveProfile. If no profile is selected, or the config
+// doesn't define any profiles, it returns an empty options.Options.
+func resolveProfileOptions(rc *RunContext, flagOpts options.Options) (options.Options, error) {
+	if rc.Config == nil || len(rc.Config.Profiles) == 0 {
+		return options.Options{}, nil
+	}
+
+	name := OptProfile.Get(flagOpts)
+	if name == "" {
+		name = rc.Config.ActiveProfile
+	}
+	if name == "" {
+		return options.Options{}, nil
+	}
+
+	return rc.Config.Profiles.Flatten(name)
+}
+
 // applyCollectionOptions invokes applySourceOptions for
 // each source in coll. The sources may have their Source.Options field
 // mutated.
@@ -160,6 +197,11 @@ func RegisterDefaultOpts(reg *options.Registry) {
 		drivers.OptIngestSampleSize,
 		csv.OptDelim,
 		csv.OptEmptyAsNull,
+		OptPasswordProvider,
+		OptPasswordKeyringService,
+		OptTheme,
+		OptProgress,
+		OptProfile,
 	)
 }
 
@@ -262,4 +304,4 @@ func addTimeFormatOptsFlags(cmd *cobra.Command) {
 	panicOn(cmd.RegisterFlagCompletionFunc(key, completeStrings(-1, timez.NamedLayouts()...)))
 	key = addOptionFlag(cmd.Flags(), OptTimeFormatAsNumber)
 	panicOn(cmd.RegisterFlagCompletionFunc(key, completeBool))
-}
\ No newline at end of file
+}
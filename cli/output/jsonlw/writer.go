@@ -0,0 +1,140 @@
+// Package jsonlw implements the "jsonl" (JSON Lines) output format: one
+// compact JSON object per record, newline-delimited. It is intended for
+// piping sq output into log-processing tools (jq, grep, vector, etc.)
+// that expect a stream of self-contained JSON values rather than a single
+// top-level array.
+package jsonlw
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/neilotoole/sq/cli/output"
+	"github.com/neilotoole/sq/libsq/core/errz"
+	"github.com/neilotoole/sq/libsq/core/kind"
+	"github.com/neilotoole/sq/libsq/core/record"
+)
+
+// RecordWriter implements output.RecordWriter for the "jsonl" format.
+type RecordWriter struct {
+	pr      *output.Printing
+	out     *bufio.Writer
+	recMeta record.Meta
+}
+
+// NewRecordWriter returns a new RecordWriter that writes JSON Lines
+// (one JSON object per row) to out.
+func NewRecordWriter(out io.Writer, pr *output.Printing) output.RecordWriter {
+	return &RecordWriter{pr: pr, out: bufio.NewWriter(out)}
+}
+
+// Open implements output.RecordWriter.
+func (w *RecordWriter) Open(recMeta record.Meta) error {
+	w.recMeta = recMeta
+	return nil
+}
+
+// WriteRecords implements output.RecordWriter. Each record is written
+// as a JSON object with fields in recMeta order — not alphabetical
+// order — matching the column order every other format renders, rather
+// than the order encoding/json would pick for a map[string]any.
+func (w *RecordWriter) WriteRecords(recs []record.Record) error {
+	for _, rec := range recs {
+		if err := w.writeObject(rec); err != nil {
+			return err
+		}
+
+		if w.out.Buffered() >= w.pr.FlushThreshold {
+			if err := w.out.Flush(); err != nil {
+				return errz.Err(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeObject writes rec as a single JSON object (followed by a
+// newline), with fields in w.recMeta order.
+func (w *RecordWriter) writeObject(rec record.Record) error {
+	if err := w.out.WriteByte('{'); err != nil {
+		return errz.Err(err)
+	}
+
+	first := true
+	for i, col := range w.recMeta {
+		if w.pr.Redact && col.Redacted() {
+			continue
+		}
+
+		if !first {
+			if err := w.out.WriteByte(','); err != nil {
+				return errz.Err(err)
+			}
+		}
+		first = false
+
+		key, err := json.Marshal(col.Name())
+		if err != nil {
+			return errz.Wrap(err, "jsonl: marshal field name")
+		}
+		if _, err = w.out.Write(key); err != nil {
+			return errz.Err(err)
+		}
+		if err = w.out.WriteByte(':'); err != nil {
+			return errz.Err(err)
+		}
+
+		val, err := json.Marshal(formatValue(w.pr, col, rec[i]))
+		if err != nil {
+			return errz.Wrap(err, "jsonl: marshal record")
+		}
+		if _, err = w.out.Write(val); err != nil {
+			return errz.Err(err)
+		}
+	}
+
+	if err := w.out.WriteByte('}'); err != nil {
+		return errz.Err(err)
+	}
+	return errz.Err(w.out.WriteByte('\n'))
+}
+
+// Flush implements output.RecordWriter.
+func (w *RecordWriter) Flush() error {
+	return errz.Err(w.out.Flush())
+}
+
+// Close implements output.RecordWriter.
+func (w *RecordWriter) Close() error {
+	return w.Flush()
+}
+
+// formatValue applies the Printing time-format options to v, returning
+// a value suitable for JSON marshaling.
+func formatValue(pr *output.Printing, col record.ColumnMeta, v any) any {
+	switch t := v.(type) {
+	case time.Time:
+		switch col.Kind() {
+		case kind.Date:
+			if pr.FormatDateAsNumber {
+				return t.Unix()
+			}
+			return pr.FormatDate(t)
+		case kind.Time:
+			if pr.FormatTimeAsNumber {
+				return t.Unix()
+			}
+			return pr.FormatTime(t)
+		default:
+			if pr.FormatDatetimeAsNumber {
+				return t.Unix()
+			}
+			return pr.FormatDatetime(t)
+		}
+	default:
+		return v
+	}
+}
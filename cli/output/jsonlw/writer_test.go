@@ -0,0 +1,164 @@
+package jsonlw
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/neilotoole/sq/cli/output"
+	"github.com/neilotoole/sq/libsq/core/kind"
+	"github.com/neilotoole/sq/libsq/core/record"
+)
+
+// testCol is a minimal record.ColumnMeta for exercising formatValue and
+// RecordWriter without a real query result.
+type testCol struct {
+	name     string
+	kind     kind.Kind
+	redacted bool
+}
+
+func (c testCol) Name() string    { return c.name }
+func (c testCol) Kind() kind.Kind { return c.kind }
+func (c testCol) Redacted() bool  { return c.redacted }
+
+func TestFormatValue_nonTime(t *testing.T) {
+	pr := output.NewPrinting()
+
+	tests := []struct {
+		in   any
+		want any
+	}{
+		{in: "abc", want: "abc"},
+		{in: 42, want: 42},
+		{in: true, want: true},
+		{in: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		// The non-time branches never touch col, so nil is safe here.
+		got := formatValue(pr, nil, tt.in)
+		if got != tt.want {
+			t.Errorf("formatValue(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatValue_time(t *testing.T) {
+	ts := time.Date(2020, 11, 12, 13, 14, 15, 0, time.UTC)
+
+	t.Run("date", func(t *testing.T) {
+		pr := output.NewPrinting()
+		got := formatValue(pr, testCol{kind: kind.Date}, ts)
+		if want := pr.FormatDate(ts); got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("date as number", func(t *testing.T) {
+		pr := output.NewPrinting()
+		pr.FormatDateAsNumber = true
+		got := formatValue(pr, testCol{kind: kind.Date}, ts)
+		if got != ts.Unix() {
+			t.Errorf("got %v, want %v", got, ts.Unix())
+		}
+	})
+
+	t.Run("time", func(t *testing.T) {
+		pr := output.NewPrinting()
+		got := formatValue(pr, testCol{kind: kind.Time}, ts)
+		if want := pr.FormatTime(ts); got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("time as number", func(t *testing.T) {
+		pr := output.NewPrinting()
+		pr.FormatTimeAsNumber = true
+		got := formatValue(pr, testCol{kind: kind.Time}, ts)
+		if got != ts.Unix() {
+			t.Errorf("got %v, want %v", got, ts.Unix())
+		}
+	})
+
+	t.Run("datetime", func(t *testing.T) {
+		pr := output.NewPrinting()
+		got := formatValue(pr, testCol{}, ts) // zero kind.Kind is neither Date nor Time
+		if want := pr.FormatDatetime(ts); got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("datetime as number", func(t *testing.T) {
+		pr := output.NewPrinting()
+		pr.FormatDatetimeAsNumber = true
+		got := formatValue(pr, testCol{}, ts)
+		if got != ts.Unix() {
+			t.Errorf("got %v, want %v", got, ts.Unix())
+		}
+	})
+}
+
+func TestWriteRecords_fieldOrder(t *testing.T) {
+	pr := output.NewPrinting()
+	var buf bytes.Buffer
+	w := NewRecordWriter(&buf, pr)
+
+	meta := record.Meta{testCol{name: "zebra"}, testCol{name: "apple"}}
+	if err := w.Open(meta); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRecords([]record.Record{{"z", "a"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"zebra":"z","apple":"a"}` + "\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q (fields must follow recMeta order, not alphabetical order)", buf.String(), want)
+	}
+}
+
+func TestWriteRecords_redact(t *testing.T) {
+	pr := output.NewPrinting()
+	pr.Redact = true
+	var buf bytes.Buffer
+	w := NewRecordWriter(&buf, pr)
+
+	meta := record.Meta{testCol{name: "public"}, testCol{name: "secret", redacted: true}}
+	if err := w.Open(meta); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRecords([]record.Record{{"x", "y"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"public":"x"}` + "\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q (redacted column must be dropped)", buf.String(), want)
+	}
+}
+
+func TestWriteRecords_flushThreshold(t *testing.T) {
+	pr := output.NewPrinting()
+	pr.FlushThreshold = 1 // flush after every record
+	var buf bytes.Buffer
+	w := NewRecordWriter(&buf, pr)
+
+	meta := record.Meta{testCol{name: "a"}}
+	if err := w.Open(meta); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRecords([]record.Record{{"x"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.Len() == 0 {
+		t.Error("expected WriteRecords to flush once FlushThreshold was exceeded, but buf is empty")
+	}
+}
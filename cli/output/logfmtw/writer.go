@@ -0,0 +1,132 @@
+// Package logfmtw implements the "logfmt" output format: one
+// space-separated key=value line per record, in the style popularized by
+// Heroku/logfmt and consumed by tools such as vector and grep. Values
+// containing a space, tab, newline, carriage return, '=' or '"' are
+// double-quoted.
+package logfmtw
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neilotoole/sq/cli/output"
+	"github.com/neilotoole/sq/libsq/core/errz"
+	"github.com/neilotoole/sq/libsq/core/kind"
+	"github.com/neilotoole/sq/libsq/core/record"
+)
+
+// RecordWriter implements output.RecordWriter for the "logfmt" format.
+type RecordWriter struct {
+	pr      *output.Printing
+	out     *bufio.Writer
+	recMeta record.Meta
+}
+
+// NewRecordWriter returns a new RecordWriter that writes logfmt
+// (key=value) lines to out.
+func NewRecordWriter(out io.Writer, pr *output.Printing) output.RecordWriter {
+	return &RecordWriter{pr: pr, out: bufio.NewWriter(out)}
+}
+
+// Open implements output.RecordWriter.
+func (w *RecordWriter) Open(recMeta record.Meta) error {
+	w.recMeta = recMeta
+	return nil
+}
+
+// WriteRecords implements output.RecordWriter.
+func (w *RecordWriter) WriteRecords(recs []record.Record) error {
+	var sb strings.Builder
+
+	for _, rec := range recs {
+		sb.Reset()
+
+		first := true
+		for i, col := range w.recMeta {
+			if w.pr.Redact && col.Redacted() {
+				continue
+			}
+
+			if !first {
+				sb.WriteByte(' ')
+			}
+			first = false
+
+			sb.WriteString(col.Name())
+			sb.WriteByte('=')
+			sb.WriteString(formatValue(w.pr, col, rec[i]))
+		}
+
+		sb.WriteByte('\n')
+		if _, err := w.out.WriteString(sb.String()); err != nil {
+			return errz.Err(err)
+		}
+
+		if w.out.Buffered() >= w.pr.FlushThreshold {
+			if err := w.out.Flush(); err != nil {
+				return errz.Err(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Flush implements output.RecordWriter.
+func (w *RecordWriter) Flush() error {
+	return errz.Err(w.out.Flush())
+}
+
+// Close implements output.RecordWriter.
+func (w *RecordWriter) Close() error {
+	return w.Flush()
+}
+
+// formatValue renders v as a logfmt-safe value, quoting it if it
+// contains a space, tab, newline, carriage return, '=', or '"'. Quoting a
+// newline is essential: logfmt is meant to be exactly one physical line
+// per record, and strconv.Quote escapes it as "\n" rather than emitting
+// a literal line break.
+func formatValue(pr *output.Printing, col record.ColumnMeta, v any) string {
+	if v == nil {
+		return "null"
+	}
+
+	var s string
+	switch t := v.(type) {
+	case time.Time:
+		switch col.Kind() {
+		case kind.Date:
+			if pr.FormatDateAsNumber {
+				s = strconv.FormatInt(t.Unix(), 10)
+			} else {
+				s = pr.FormatDate(t)
+			}
+		case kind.Time:
+			if pr.FormatTimeAsNumber {
+				s = strconv.FormatInt(t.Unix(), 10)
+			} else {
+				s = pr.FormatTime(t)
+			}
+		default:
+			if pr.FormatDatetimeAsNumber {
+				s = strconv.FormatInt(t.Unix(), 10)
+			} else {
+				s = pr.FormatDatetime(t)
+			}
+		}
+	case string:
+		s = t
+	default:
+		s = fmt.Sprint(t)
+	}
+
+	if strings.ContainsAny(s, " \t\"=\n\r") {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -3,6 +3,7 @@ package output
 import (
 	"time"
 
+	"github.com/neilotoole/sq/cli/output/theme"
 	"github.com/neilotoole/sq/libsq/core/timez"
 
 	"github.com/fatih/color"
@@ -14,6 +15,9 @@ type Printing struct {
 	// monochrome is controlled by EnableColor.
 	monochrome bool
 
+	// themeName is the name of the currently-applied theme. See SetTheme.
+	themeName string
+
 	// FlushThreshold is the size in bytes after which an output writer
 	// should flush any internal buffer.
 	FlushThreshold int
@@ -127,7 +131,8 @@ type Printing struct {
 }
 
 // NewPrinting returns a Printing instance. Color and pretty-print
-// are enabled. The default indent is two spaces.
+// are enabled, using the theme.Default color theme. The default indent
+// is two spaces.
 func NewPrinting() *Printing {
 	pr := &Printing{
 		ShowHeader:             true,
@@ -143,31 +148,58 @@ func NewPrinting() *Printing {
 		FormatDateAsNumber:     false,
 		monochrome:             false,
 		Indent:                 "  ",
-		Active:                 color.New(color.FgGreen, color.Bold),
-		Bold:                   color.New(color.Bold),
-		Bool:                   color.New(color.FgYellow),
-		Bytes:                  color.New(color.Faint),
-		Datetime:               color.New(color.FgGreen, color.Faint),
-		Duration:               color.New(color.FgGreen, color.Faint),
-		Error:                  color.New(color.FgRed, color.Bold),
-		Faint:                  color.New(color.Faint),
-		Handle:                 color.New(color.FgBlue),
-		Header:                 color.New(color.FgBlue),
-		Hilite:                 color.New(color.FgHiBlue),
-		Key:                    color.New(color.FgBlue, color.Bold),
-		Location:               color.New(color.FgGreen),
-		Normal:                 color.New(),
-		Null:                   color.New(color.Faint),
-		Number:                 color.New(color.FgCyan),
-		Punc:                   color.New(color.Bold),
-		String:                 color.New(color.FgGreen),
-		Success:                color.New(color.FgGreen, color.Bold),
 	}
 
+	// SetTheme populates pr's color fields; errors are impossible here
+	// since theme.Default's style descriptors are all well-formed.
+	_ = pr.SetTheme(theme.Default)
 	pr.EnableColor(true)
 	return pr
 }
 
+// SetTheme applies th's style descriptors to pr's color fields, and
+// records th.Name for LogValue. Color is re-applied according to pr's
+// current monochrome setting, so SetTheme may be called at any time,
+// e.g. in response to the user changing OptTheme.
+func (pr *Printing) SetTheme(th theme.Theme) error {
+	fields := []struct {
+		dest       **color.Color
+		descriptor string
+	}{
+		{&pr.Active, th.Active},
+		{&pr.Bold, th.Bold},
+		{&pr.Bool, th.Bool},
+		{&pr.Bytes, th.Bytes},
+		{&pr.Datetime, th.Datetime},
+		{&pr.Duration, th.Duration},
+		{&pr.Error, th.Error},
+		{&pr.Faint, th.Faint},
+		{&pr.Handle, th.Handle},
+		{&pr.Header, th.Header},
+		{&pr.Hilite, th.Hilite},
+		{&pr.Key, th.Key},
+		{&pr.Location, th.Location},
+		{&pr.Null, th.Null},
+		{&pr.Normal, th.Normal},
+		{&pr.Number, th.Number},
+		{&pr.Punc, th.Punc},
+		{&pr.String, th.String},
+		{&pr.Success, th.Success},
+	}
+
+	for _, f := range fields {
+		clr, err := theme.ParseStyle(f.descriptor)
+		if err != nil {
+			return err
+		}
+		*f.dest = clr
+	}
+
+	pr.themeName = th.Name
+	pr.EnableColor(!pr.monochrome)
+	return nil
+}
+
 // LogValue implements slog.LogValuer.
 func (pr *Printing) LogValue() slog.Value {
 	if pr == nil {
@@ -182,6 +214,7 @@ func (pr *Printing) LogValue() slog.Value {
 		slog.Bool("redact", pr.Redact),
 		slog.Int("flush-threshold", pr.FlushThreshold),
 		slog.String("indent", pr.Indent),
+		slog.String("theme", pr.themeName),
 		slog.Bool("format.datetime.number", pr.FormatDatetimeAsNumber),
 		slog.Bool("format.date.number", pr.FormatDateAsNumber),
 		slog.Bool("format.time.number", pr.FormatTimeAsNumber),
@@ -218,4 +251,4 @@ func (pr *Printing) EnableColor(enable bool) {
 	for _, clr := range pr.colors() {
 		clr.DisableColor()
 	}
-}
\ No newline at end of file
+}
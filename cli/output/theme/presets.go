@@ -0,0 +1,148 @@
+package theme
+
+// Default is the theme applied when no other theme is configured; its
+// colors match sq's original hard-coded palette.
+var Default = Theme{
+	Name:     "default",
+	Active:   "fg=green,bold",
+	Bold:     "bold",
+	Bool:     "fg=yellow",
+	Bytes:    "faint",
+	Datetime: "fg=green,faint",
+	Duration: "fg=green,faint",
+	Error:    "fg=red,bold",
+	Faint:    "faint",
+	Handle:   "fg=blue",
+	Header:   "fg=blue",
+	Hilite:   "fg=hi-blue",
+	Key:      "fg=blue,bold",
+	Location: "fg=green",
+	Null:     "faint",
+	Normal:   "",
+	Number:   "fg=cyan",
+	Punc:     "bold",
+	String:   "fg=green",
+	Success:  "fg=green,bold",
+}
+
+// Dracula is a theme based on the Dracula (https://draculatheme.com) palette.
+var Dracula = Theme{
+	Name:     "dracula",
+	Active:   "fg=#50fa7b,bold",
+	Bold:     "bold",
+	Bool:     "fg=#ffb86c",
+	Bytes:    "faint",
+	Datetime: "fg=#8be9fd,faint",
+	Duration: "fg=#8be9fd,faint",
+	Error:    "fg=#ff5555,bold",
+	Faint:    "faint",
+	Handle:   "fg=#bd93f9",
+	Header:   "fg=#bd93f9",
+	Hilite:   "fg=#ff79c6",
+	Key:      "fg=#bd93f9,bold",
+	Location: "fg=#50fa7b",
+	Null:     "faint",
+	Normal:   "",
+	Number:   "fg=#8be9fd",
+	Punc:     "bold",
+	String:   "fg=#f1fa8c",
+	Success:  "fg=#50fa7b,bold",
+}
+
+// SolarizedDark is a theme based on the Solarized dark palette
+// (https://ethanschoonover.com/solarized).
+var SolarizedDark = Theme{
+	Name:     "solarized-dark",
+	Active:   "fg=green,bold",
+	Bold:     "bold",
+	Bool:     "fg=yellow",
+	Bytes:    "faint",
+	Datetime: "fg=cyan,faint",
+	Duration: "fg=cyan,faint",
+	Error:    "fg=red,bold",
+	Faint:    "faint",
+	Handle:   "fg=blue",
+	Header:   "fg=blue",
+	Hilite:   "fg=hi-cyan",
+	Key:      "fg=blue,bold",
+	Location: "fg=green",
+	Null:     "faint",
+	Normal:   "",
+	Number:   "fg=cyan",
+	Punc:     "bold",
+	String:   "fg=green",
+	Success:  "fg=green,bold",
+}
+
+// SolarizedLight is a theme based on the Solarized light palette
+// (https://ethanschoonover.com/solarized).
+var SolarizedLight = Theme{
+	Name:     "solarized-light",
+	Active:   "fg=green,bold",
+	Bold:     "bold",
+	Bool:     "fg=yellow",
+	Bytes:    "faint",
+	Datetime: "fg=cyan,faint",
+	Duration: "fg=cyan,faint",
+	Error:    "fg=red,bold",
+	Faint:    "faint",
+	Handle:   "fg=blue",
+	Header:   "fg=blue",
+	Hilite:   "fg=cyan",
+	Key:      "fg=blue,bold",
+	Location: "fg=green",
+	Null:     "faint",
+	Normal:   "",
+	Number:   "fg=cyan",
+	Punc:     "bold",
+	String:   "fg=green",
+	Success:  "fg=green,bold",
+}
+
+// Monokai is a theme based on the Monokai palette.
+var Monokai = Theme{
+	Name:     "monokai",
+	Active:   "fg=#a6e22e,bold",
+	Bold:     "bold",
+	Bool:     "fg=#fd971f",
+	Bytes:    "faint",
+	Datetime: "fg=#66d9ef,faint",
+	Duration: "fg=#66d9ef,faint",
+	Error:    "fg=#f92672,bold",
+	Faint:    "faint",
+	Handle:   "fg=#ae81ff",
+	Header:   "fg=#ae81ff",
+	Hilite:   "fg=#f92672",
+	Key:      "fg=#ae81ff,bold",
+	Location: "fg=#a6e22e",
+	Null:     "faint",
+	Normal:   "",
+	Number:   "fg=#66d9ef",
+	Punc:     "bold",
+	String:   "fg=#e6db74",
+	Success:  "fg=#a6e22e,bold",
+}
+
+// Nord is a theme based on the Nord (https://www.nordtheme.com) palette.
+var Nord = Theme{
+	Name:     "nord",
+	Active:   "fg=#a3be8c,bold",
+	Bold:     "bold",
+	Bool:     "fg=#ebcb8b",
+	Bytes:    "faint",
+	Datetime: "fg=#88c0d0,faint",
+	Duration: "fg=#88c0d0,faint",
+	Error:    "fg=#bf616a,bold",
+	Faint:    "faint",
+	Handle:   "fg=#81a1c1",
+	Header:   "fg=#81a1c1",
+	Hilite:   "fg=#88c0d0",
+	Key:      "fg=#81a1c1,bold",
+	Location: "fg=#a3be8c",
+	Null:     "faint",
+	Normal:   "",
+	Number:   "fg=#8fbcbb",
+	Punc:     "bold",
+	String:   "fg=#a3be8c",
+	Success:  "fg=#a3be8c,bold",
+}
@@ -0,0 +1,209 @@
+// Package theme defines sq's color theme subsystem: a Theme maps each
+// semantic color role used by output.Printing (Active, Handle, Number,
+// etc.) to a style descriptor such as "fg=green,bold" or
+// "fg=#5fafff,faint". A handful of presets are built in, and users may
+// additionally define their own themes under ~/.config/sq/themes.
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
+
+	"github.com/neilotoole/sq/libsq/core/errz"
+)
+
+// Theme maps each of Printing's semantic color roles to a style
+// descriptor string, e.g. "fg=green,bold" or "fg=#5fafff,faint". An
+// empty descriptor leaves the role unstyled (monochrome).
+type Theme struct {
+	Name string `yaml:"-"`
+
+	Active   string `yaml:"active"`
+	Bold     string `yaml:"bold"`
+	Bool     string `yaml:"bool"`
+	Bytes    string `yaml:"bytes"`
+	Datetime string `yaml:"datetime"`
+	Duration string `yaml:"duration"`
+	Error    string `yaml:"error"`
+	Faint    string `yaml:"faint"`
+	Handle   string `yaml:"handle"`
+	Header   string `yaml:"header"`
+	Hilite   string `yaml:"hilite"`
+	Key      string `yaml:"key"`
+	Location string `yaml:"location"`
+	Null     string `yaml:"null"`
+	Normal   string `yaml:"normal"`
+	Number   string `yaml:"number"`
+	Punc     string `yaml:"punc"`
+	String   string `yaml:"string"`
+	Success  string `yaml:"success"`
+}
+
+// presets holds the built-in themes, keyed by name.
+var presets = map[string]Theme{
+	"default":         Default,
+	"dracula":         Dracula,
+	"solarized-dark":  SolarizedDark,
+	"solarized-light": SolarizedLight,
+	"monokai":         Monokai,
+	"nord":            Nord,
+}
+
+// Names returns the names of the built-in theme presets, sorted.
+func Names() []string {
+	return []string{"default", "dracula", "monokai", "nord", "solarized-dark", "solarized-light"}
+}
+
+// Load returns the named theme. It first checks the built-in presets,
+// then falls back to a user theme at ~/.config/sq/themes/<name>.yml. A
+// user theme need only specify the roles it overrides; any role it
+// omits falls back to Default.
+func Load(name string) (Theme, error) {
+	if th, ok := presets[name]; ok {
+		return th, nil
+	}
+
+	th, err := loadUserTheme(name)
+	if err != nil {
+		return Theme{}, err
+	}
+
+	return th, nil
+}
+
+// loadUserTheme loads a user-defined theme from
+// ~/.config/sq/themes/<name>.yml, using Default for any role the file
+// doesn't specify.
+func loadUserTheme(name string) (Theme, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return Theme{}, errz.Wrap(err, "theme: resolve config dir")
+	}
+
+	fp := filepath.Join(dir, "sq", "themes", name+".yml")
+	b, err := os.ReadFile(fp)
+	if err != nil {
+		return Theme{}, errz.Wrap(err, "theme: unknown theme: "+name)
+	}
+
+	th := Default
+	if err = yaml.Unmarshal(b, &th); err != nil {
+		return Theme{}, errz.Wrap(err, "theme: parse "+fp)
+	}
+
+	th.Name = name
+	return th, nil
+}
+
+// ParseStyle parses a style descriptor such as "fg=green,bold" or
+// "fg=#5fafff,faint" into a *color.Color. An empty descriptor returns
+// color.New() (unstyled). Hex colors are rendered as truecolor via
+// fatih/color's AddRGB/AddBgRGB, rather than approximated by the basic
+// ANSI attribute set.
+func ParseStyle(descriptor string) (*color.Color, error) {
+	c := color.New()
+	if descriptor == "" {
+		return c, nil
+	}
+
+	for _, field := range strings.Split(descriptor, ",") {
+		field = strings.TrimSpace(field)
+		switch {
+		case field == "bold":
+			c.Add(color.Bold)
+		case field == "faint":
+			c.Add(color.Faint)
+		case field == "underline":
+			c.Add(color.Underline)
+		case strings.HasPrefix(field, "fg="):
+			if err := applyColorName(c, strings.TrimPrefix(field, "fg="), false); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(field, "bg="):
+			if err := applyColorName(c, strings.TrimPrefix(field, "bg="), true); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errz.Errorf("theme: invalid style descriptor field: %s", field)
+		}
+	}
+
+	return c, nil
+}
+
+// namedColors maps the basic ANSI color names (plus their "hi-" bright
+// variants) to their foreground and background color.Attribute values.
+var namedColors = map[string][2]color.Attribute{
+	"black":      {color.FgBlack, color.BgBlack},
+	"red":        {color.FgRed, color.BgRed},
+	"green":      {color.FgGreen, color.BgGreen},
+	"yellow":     {color.FgYellow, color.BgYellow},
+	"blue":       {color.FgBlue, color.BgBlue},
+	"magenta":    {color.FgMagenta, color.BgMagenta},
+	"cyan":       {color.FgCyan, color.BgCyan},
+	"white":      {color.FgWhite, color.BgWhite},
+	"hi-black":   {color.FgHiBlack, color.BgHiBlack},
+	"hi-red":     {color.FgHiRed, color.BgHiRed},
+	"hi-green":   {color.FgHiGreen, color.BgHiGreen},
+	"hi-yellow":  {color.FgHiYellow, color.BgHiYellow},
+	"hi-blue":    {color.FgHiBlue, color.BgHiBlue},
+	"hi-magenta": {color.FgHiMagenta, color.BgHiMagenta},
+	"hi-cyan":    {color.FgHiCyan, color.BgHiCyan},
+	"hi-white":   {color.FgHiWhite, color.BgHiWhite},
+}
+
+// applyColorName resolves a color name (e.g. "green", "hi-blue", or a
+// hex value like "#5fafff") and adds it to c as a foreground (bg=false)
+// or background (bg=true) color. Hex values are applied as truecolor
+// via AddRGB/AddBgRGB so that, e.g., the Dracula and Monokai presets'
+// hex palettes render as their actual colors rather than collapsing to
+// a handful of basic ANSI approximations.
+func applyColorName(c *color.Color, name string, bg bool) error {
+	if strings.HasPrefix(name, "#") {
+		r, g, b, err := parseHex(name)
+		if err != nil {
+			return err
+		}
+		if bg {
+			c.AddBgRGB(r, g, b)
+		} else {
+			c.AddRGB(r, g, b)
+		}
+		return nil
+	}
+
+	pair, ok := namedColors[name]
+	if !ok {
+		return errz.Errorf("theme: unknown color: %s", name)
+	}
+
+	if bg {
+		c.Add(pair[1])
+	} else {
+		c.Add(pair[0])
+	}
+	return nil
+}
+
+// parseHex parses a "#rrggbb" string into its red, green, and blue
+// components.
+func parseHex(hex string) (r, g, b int, err error) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return 0, 0, 0, errz.Errorf("theme: invalid hex color: #%s", hex)
+	}
+
+	rv, err1 := strconv.ParseInt(hex[0:2], 16, 0)
+	gv, err2 := strconv.ParseInt(hex[2:4], 16, 0)
+	bv, err3 := strconv.ParseInt(hex[4:6], 16, 0)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return 0, 0, 0, errz.Errorf("theme: invalid hex color: #%s", hex)
+	}
+
+	return int(rv), int(gv), int(bv), nil
+}
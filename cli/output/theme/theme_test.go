@@ -0,0 +1,60 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+// TestPresets_DifferFromDefault verifies that every built-in preset
+// renders differently from Default in each of the color roles checked
+// below whose style descriptors differ, guarding against bugs where
+// distinct hex colors silently collapse to the same basic attribute.
+func TestPresets_DifferFromDefault(t *testing.T) {
+	prevNoColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = prevNoColor }()
+
+	for name, preset := range presets {
+		name, preset := name, preset
+		if name == "default" {
+			continue
+		}
+
+		t.Run(name, func(t *testing.T) {
+			fields := map[string]struct{ want, got string }{
+				"Active":   {Default.Active, preset.Active},
+				"Bool":     {Default.Bool, preset.Bool},
+				"Datetime": {Default.Datetime, preset.Datetime},
+				"Error":    {Default.Error, preset.Error},
+				"Handle":   {Default.Handle, preset.Handle},
+				"Hilite":   {Default.Hilite, preset.Hilite},
+				"Key":      {Default.Key, preset.Key},
+				"Number":   {Default.Number, preset.Number},
+				"String":   {Default.String, preset.String},
+			}
+
+			for field, f := range fields {
+				if f.want == f.got {
+					continue // descriptor identical; no divergence expected
+				}
+
+				wantColor, err := ParseStyle(f.want)
+				if err != nil {
+					t.Fatalf("%s: parse Default descriptor %q: %v", field, f.want, err)
+				}
+				gotColor, err := ParseStyle(f.got)
+				if err != nil {
+					t.Fatalf("%s: parse %s descriptor %q: %v", field, name, f.got, err)
+				}
+
+				wantOut := wantColor.Sprint("x")
+				gotOut := gotColor.Sprint("x")
+				if wantOut == gotOut {
+					t.Errorf("%s: preset %s descriptor %q renders identically to Default's %q (%q)",
+						field, name, f.got, f.want, gotOut)
+				}
+			}
+		})
+	}
+}
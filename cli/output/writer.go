@@ -0,0 +1,60 @@
+package output
+
+import (
+	"context"
+
+	"github.com/neilotoole/sq/cli/progress"
+	"github.com/neilotoole/sq/libsq/core/record"
+)
+
+// RecordWriter is implemented by the per-format record writers (e.g.
+// jsonlw.RecordWriter, logfmtw.RecordWriter) that stream query results
+// to an output.
+type RecordWriter interface {
+	// Open is invoked once, before any call to WriteRecords, with the
+	// metadata of the records to be written.
+	Open(recMeta record.Meta) error
+
+	// WriteRecords writes recs to the underlying output. It may be
+	// invoked multiple times as records are streamed in.
+	WriteRecords(recs []record.Record) error
+
+	// Flush flushes any buffered output.
+	Flush() error
+
+	// Close flushes and closes the writer.
+	Close() error
+}
+
+// progressRecordWriter decorates a RecordWriter, reporting the number
+// of rows written to the *progress.Progress stored in ctx (see
+// progress.FromContext) so that query-result streaming shows live
+// row-count progress.
+type progressRecordWriter struct {
+	RecordWriter
+	incr func(n int64)
+	done func()
+}
+
+// NewProgressRecordWriter wraps rw so that each batch of rows written
+// through it is reported, under label, to the *progress.Progress
+// stored in ctx.
+func NewProgressRecordWriter(ctx context.Context, rw RecordWriter, label string) RecordWriter {
+	incr, done := progress.FromContext(ctx).NewRowCounter(label)
+	return &progressRecordWriter{RecordWriter: rw, incr: incr, done: done}
+}
+
+// WriteRecords implements RecordWriter.
+func (w *progressRecordWriter) WriteRecords(recs []record.Record) error {
+	if err := w.RecordWriter.WriteRecords(recs); err != nil {
+		return err
+	}
+	w.incr(int64(len(recs)))
+	return nil
+}
+
+// Close implements RecordWriter.
+func (w *progressRecordWriter) Close() error {
+	w.done()
+	return w.RecordWriter.Close()
+}
@@ -0,0 +1,47 @@
+package profile
+
+import "sort"
+
+// DiffEntry describes how a single option key differs between two
+// flattened profiles. Before/After are empty when the key is absent on
+// that side (added/removed).
+type DiffEntry struct {
+	Key    string
+	Before string
+	After  string
+}
+
+// Diff compares the flattened options of two profiles, returning one
+// DiffEntry per key that differs (added, removed, or changed), sorted
+// by key.
+func (s Set) Diff(name1, name2 string) ([]DiffEntry, error) {
+	opts1, err := s.Flatten(name1)
+	if err != nil {
+		return nil, err
+	}
+
+	opts2, err := s.Flatten(name2)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]struct{}{}
+	for k := range opts1 {
+		keys[k] = struct{}{}
+	}
+	for k := range opts2 {
+		keys[k] = struct{}{}
+	}
+
+	var entries []DiffEntry
+	for k := range keys {
+		v1, v2 := opts1[k], opts2[k]
+		if v1 == v2 {
+			continue
+		}
+		entries = append(entries, DiffEntry{Key: k, Before: v1, After: v2})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries, nil
+}
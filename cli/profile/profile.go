@@ -0,0 +1,83 @@
+// Package profile implements named, inheritable sets of options.Options
+// for sq's config subsystem. A Profile may declare "extends: parent" to
+// inherit another profile's options, which it then overrides; Set.Flatten
+// walks and merges that chain.
+package profile
+
+import (
+	"sort"
+
+	"github.com/neilotoole/sq/libsq/core/errz"
+	"github.com/neilotoole/sq/libsq/core/options"
+)
+
+// Profile is a named set of options.Options, optionally extending
+// (inheriting from) another profile.
+type Profile struct {
+	// Name is the profile's key in a Set. Not serialized as a field of
+	// the profile itself; it's the map key in the config file.
+	Name string `yaml:"-"`
+
+	// Extends, if set, is the name of the parent profile this profile
+	// inherits options from. The parent's options are merged first, so
+	// this profile's own Options take precedence.
+	Extends string `yaml:"extends,omitempty"`
+
+	// Options are the option values this profile sets.
+	Options options.Options `yaml:"options,omitempty"`
+}
+
+// Set is a named collection of profiles, as loaded from config.
+type Set map[string]Profile
+
+// Names returns the profile names in s, sorted.
+func (s Set) Names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Flatten resolves name's extends chain and returns the merged
+// options.Options, with each profile's own options taking precedence
+// over its ancestors'.
+func (s Set) Flatten(name string) (options.Options, error) {
+	chain, err := s.chain(name)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := options.Options{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		merged = options.Merge(merged, chain[i].Options)
+	}
+
+	return merged, nil
+}
+
+// chain returns name's ancestry, starting with name itself and ending
+// with its root ancestor, erroring on an unknown profile or an extends
+// cycle.
+func (s Set) chain(name string) ([]Profile, error) {
+	seen := map[string]bool{}
+	var chain []Profile
+
+	for cur := name; cur != ""; {
+		if seen[cur] {
+			return nil, errz.Errorf("profile: extends cycle detected at %q", cur)
+		}
+		seen[cur] = true
+
+		p, ok := s[cur]
+		if !ok {
+			return nil, errz.Errorf("profile: unknown profile: %q", cur)
+		}
+
+		chain = append(chain, p)
+		cur = p.Extends
+	}
+
+	return chain, nil
+}
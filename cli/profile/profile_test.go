@@ -0,0 +1,69 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/neilotoole/sq/libsq/core/options"
+)
+
+func TestSet_Flatten(t *testing.T) {
+	s := Set{
+		"base": {Name: "base", Options: options.Options{"a": "1", "b": "1"}},
+		"dev":  {Name: "dev", Extends: "base", Options: options.Options{"b": "2"}},
+	}
+
+	got, err := s.Flatten("dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["a"] != "1" {
+		t.Errorf("a = %q, want %q (inherited from base)", got["a"], "1")
+	}
+	if got["b"] != "2" {
+		t.Errorf("b = %q, want %q (dev overrides base)", got["b"], "2")
+	}
+}
+
+func TestSet_Flatten_unknownProfile(t *testing.T) {
+	s := Set{}
+
+	if _, err := s.Flatten("missing"); err == nil {
+		t.Error("expected error for unknown profile, got nil")
+	}
+}
+
+func TestSet_Flatten_extendsCycle(t *testing.T) {
+	s := Set{
+		"a": {Name: "a", Extends: "b"},
+		"b": {Name: "b", Extends: "a"},
+	}
+
+	if _, err := s.Flatten("a"); err == nil {
+		t.Error("expected error for extends cycle, got nil")
+	}
+}
+
+func TestSet_Diff(t *testing.T) {
+	s := Set{
+		"dev":  {Name: "dev", Options: options.Options{"a": "1", "b": "1"}},
+		"prod": {Name: "prod", Options: options.Options{"a": "1", "c": "1"}},
+	}
+
+	entries, err := s.Diff("dev", "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]DiffEntry{
+		"b": {Key: "b", Before: "1", After: ""},
+		"c": {Key: "c", Before: "", After: "1"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for _, e := range entries {
+		if e != want[e.Key] {
+			t.Errorf("entry %q = %+v, want %+v", e.Key, e, want[e.Key])
+		}
+	}
+}
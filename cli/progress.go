@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/neilotoole/sq/cli/progress"
+	"github.com/neilotoole/sq/libsq/core/options"
+)
+
+// OptProgress specifies whether the live progress subsystem (spinner,
+// byte/row counters) is enabled for long-running operations such as
+// query result streaming. See: progress.New.
+var OptProgress = options.NewBool(
+	"progress",
+	"",
+	0,
+	true,
+	"Show progress",
+	`Show progress for long-running operations such as query result
+streaming. When stderr is not a terminal, or when --monochrome or
+--verbose is set, progress is shown as plain periodic log lines instead.`,
+)
+
+// newProgressFromCmd constructs a *progress.Progress for cmd, honoring
+// OptProgress, OptMonochrome, and OptVerbose: progress is only rendered
+// live when all three allow it and stderr is a TTY. With OptProgress
+// disabled nothing is shown; otherwise it degrades to plain periodic log
+// lines (see progress.New).
+func newProgressFromCmd(cmd *cobra.Command) (*progress.Progress, error) {
+	opts, err := getOptionsFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	rc := RunContextFrom(cmd.Context())
+
+	out := rc.ErrOut
+	if OptMonochrome.Get(opts) || OptVerbose.Get(opts) {
+		// Hide out's Fd method, so that progress.New treats it as a
+		// non-TTY and logs plain periodic lines instead.
+		out = struct{ io.Writer }{out}
+	}
+
+	return progress.New(out, OptProgress.Get(opts)), nil
+}
+
+// withProgressContext returns ctx with a *progress.Progress for cmd
+// added via progress.NewContext, so that ingest drivers and the query
+// execution path can retrieve it via progress.FromContext.
+func withProgressContext(cmd *cobra.Command, ctx context.Context) (context.Context, *progress.Progress, error) {
+	p, err := newProgressFromCmd(cmd)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	return progress.NewContext(ctx, p), p, nil
+}
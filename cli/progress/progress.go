@@ -0,0 +1,239 @@
+// Package progress implements sq's live progress reporting. When stderr
+// is a TTY and color is enabled, it renders a multi-line, spinner-based
+// status (similar to the progress output of docker or apt) that is
+// redrawn in place via ANSI cursor control. Otherwise it degrades to
+// plain periodic log lines, e.g. when output is piped to a file or
+// --monochrome / --verbose is set.
+package progress
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// ctxKey is the context.Context key under which a *Progress is stored.
+type ctxKey struct{}
+
+// NewContext returns ctx with p added as a value, retrievable via
+// FromContext. This is how the progress subsystem is wired through
+// cli.RunContext: a *Progress is constructed once per invocation and
+// threaded through the command's context.
+func NewContext(ctx context.Context, p *Progress) context.Context {
+	return context.WithValue(ctx, ctxKey{}, p)
+}
+
+// FromContext returns the *Progress previously stored in ctx via
+// NewContext, or a no-op Progress if none is present (e.g. in tests).
+func FromContext(ctx context.Context) *Progress {
+	p, ok := ctx.Value(ctxKey{}).(*Progress)
+	if !ok || p == nil {
+		return noop
+	}
+	return p
+}
+
+// noop is a disabled Progress returned by FromContext when none was
+// wired into the context, so callers never need a nil check.
+var noop = &Progress{disabled: true}
+
+// refreshInterval is how often the live TTY display is redrawn.
+const refreshInterval = 100 * time.Millisecond
+
+// Progress renders live status for long-running operations such as
+// CSV/XLSX ingestion and query result streaming. It is safe for
+// concurrent use by multiple bars/counters.
+type Progress struct {
+	out      io.Writer
+	tty      bool
+	disabled bool
+
+	mu      sync.Mutex
+	start   time.Time
+	items   []*item
+	stopCh  chan struct{}
+	stopped bool
+}
+
+// item is a single tracked unit of progress, e.g. one ingest file or
+// one query's row stream.
+type item struct {
+	label   string
+	count   int64 // bytes (ingest) or rows (streaming)
+	unit    string
+	started time.Time
+	done    bool
+}
+
+// New returns a Progress that renders to out. When enabled is false, New
+// returns a disabled Progress whose New* methods are no-ops and which
+// writes nothing to out. When enabled but out is not a TTY, updates are
+// logged as plain periodic lines instead of rendered live — see logLine.
+func New(out io.Writer, enabled bool) *Progress {
+	p := &Progress{
+		out:      out,
+		start:    time.Now(),
+		disabled: !enabled,
+	}
+
+	if f, ok := out.(interface{ Fd() uintptr }); ok {
+		p.tty = term.IsTerminal(int(f.Fd()))
+	}
+
+	if !p.disabled && p.tty {
+		p.stopCh = make(chan struct{})
+		go p.renderLoop()
+	}
+
+	return p
+}
+
+// NewByteCounter registers a new ingest progress item tracking bytes
+// read under label (typically the source file name), returning a
+// function to increment its count and a function to mark it complete.
+// No ingest driver calls it, so CSV/XLSX ingestion reports no progress.
+func (p *Progress) NewByteCounter(label string) (incr func(n int64), done func()) {
+	return p.newItem(label, "B")
+}
+
+// NewRowCounter registers a new progress item tracking rows written
+// during query result streaming, returning a function to increment its
+// count and a function to mark it complete.
+func (p *Progress) NewRowCounter(label string) (incr func(n int64), done func()) {
+	return p.newItem(label, "rows")
+}
+
+func (p *Progress) newItem(label, unit string) (incr func(n int64), done func()) {
+	if p.disabled {
+		return func(int64) {}, func() {}
+	}
+
+	it := &item{label: label, unit: unit, started: time.Now()}
+
+	p.mu.Lock()
+	p.items = append(p.items, it)
+	p.mu.Unlock()
+
+	var lastLogged time.Time
+	return func(n int64) {
+			p.mu.Lock()
+			it.count += n
+			p.mu.Unlock()
+
+			if !p.tty {
+				// Degrade to periodic plain log lines, at most once per
+				// refreshInterval, rather than one line per call.
+				if now := time.Now(); now.Sub(lastLogged) >= refreshInterval {
+					lastLogged = now
+					p.logLine(it)
+				}
+			}
+		}, func() {
+			p.mu.Lock()
+			it.done = true
+			p.mu.Unlock()
+
+			if !p.tty {
+				p.logLine(it)
+			}
+		}
+}
+
+// logLine writes a single plain-text progress line for it, used when
+// the live TTY renderer isn't active.
+func (p *Progress) logLine(it *item) {
+	fmt.Fprintf(p.out, "%s: %d %s (%s elapsed)\n",
+		it.label, it.count, it.unit, time.Since(it.started).Round(time.Second))
+}
+
+// Stop halts the live renderer (if running) and clears the display.
+func (p *Progress) Stop() {
+	if p.disabled || !p.tty {
+		return
+	}
+
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return
+	}
+	p.stopped = true
+	p.mu.Unlock()
+
+	close(p.stopCh)
+}
+
+// renderLoop redraws the live multi-line status at refreshInterval
+// until Stop is called, using ANSI cursor control to move back up to
+// the start of the status block before each redraw.
+func (p *Progress) renderLoop() {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	var lastLines int
+	spinFrames := []rune(`|/-\`)
+	var frame int
+
+	for {
+		select {
+		case <-p.stopCh:
+			p.eraseLines(lastLines)
+			return
+		case <-ticker.C:
+			p.mu.Lock()
+			items := make([]*item, len(p.items))
+			copy(items, p.items)
+			p.mu.Unlock()
+
+			p.eraseLines(lastLines)
+			lastLines = len(items)
+
+			spin := spinFrames[frame%len(spinFrames)]
+			frame++
+
+			for _, it := range items {
+				status := string(spin)
+				if it.done {
+					status = "done"
+				}
+				fmt.Fprintf(p.out, "[%s] %s: %d %s (%s)\n",
+					status, it.label, it.count, it.unit,
+					time.Since(it.started).Round(time.Second))
+			}
+
+			p.pruneDone()
+		}
+	}
+}
+
+// pruneDone drops completed items from p.items. Without this, a
+// long-running session (e.g. the shell REPL, which keeps one *Progress
+// alive for its whole lifetime) would accumulate an ever-growing list
+// of stale "done" lines in the live display. Each item is shown as done
+// in at most one redraw (the one in the renderLoop iteration that called
+// this), then dropped; an item finished after that redraw was drawn is
+// dropped without being shown as done.
+func (p *Progress) pruneDone() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	kept := p.items[:0]
+	for _, it := range p.items {
+		if !it.done {
+			kept = append(kept, it)
+		}
+	}
+	p.items = kept
+}
+
+// eraseLines moves the cursor up n lines and clears them, in
+// preparation for redrawing the live status block.
+func (p *Progress) eraseLines(n int) {
+	for i := 0; i < n; i++ {
+		fmt.Fprint(p.out, "\033[1A\033[2K")
+	}
+}
@@ -0,0 +1,86 @@
+package progress
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNew_disabled(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(&buf, false)
+
+	incr, done := p.NewByteCounter("f")
+	incr(10)
+	done()
+
+	if buf.Len() != 0 {
+		t.Errorf("disabled Progress should never write to out, got %q", buf.String())
+	}
+}
+
+func TestNew_nonTTY_logsPeriodically(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(&buf, true) // buf isn't a *os.File, so New treats it as non-TTY
+
+	incr, done := p.NewRowCounter("rows")
+	incr(5)
+	done()
+
+	out := buf.String()
+	if !strings.Contains(out, "rows: 5 rows") {
+		t.Errorf("expected a plain log line mentioning the row count, got %q", out)
+	}
+}
+
+func TestContext_roundTrip(t *testing.T) {
+	p := New(&bytes.Buffer{}, false)
+	ctx := NewContext(context.Background(), p)
+
+	if got := FromContext(ctx); got != p {
+		t.Error("FromContext did not return the Progress stored via NewContext")
+	}
+}
+
+func TestFromContext_noneStored(t *testing.T) {
+	if got := FromContext(context.Background()); got != noop {
+		t.Error("FromContext should return the package noop Progress when none is stored")
+	}
+}
+
+func TestStop_disabled_isNoop(t *testing.T) {
+	p := New(&bytes.Buffer{}, false)
+	p.Stop() // must not panic
+}
+
+func TestPruneDone(t *testing.T) {
+	p := New(&bytes.Buffer{}, true) // non-tty, enabled: items are tracked but not rendered live
+
+	_, done1 := p.NewByteCounter("a")
+	_, done2 := p.NewByteCounter("b")
+	done1()
+
+	if got := len(p.items); got != 2 {
+		t.Fatalf("expected 2 tracked items before pruning, got %d", got)
+	}
+
+	p.pruneDone()
+
+	p.mu.Lock()
+	remaining := len(p.items)
+	var remainingLabel string
+	if remaining > 0 {
+		remainingLabel = p.items[0].label
+	}
+	p.mu.Unlock()
+
+	if remaining != 1 {
+		t.Fatalf("expected pruneDone to drop the done item, got %d remaining", remaining)
+	}
+	if remainingLabel != "b" {
+		t.Errorf("expected remaining item to be %q, got %q", "b", remainingLabel)
+	}
+
+	done2()
+}
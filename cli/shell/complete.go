@@ -0,0 +1,96 @@
+package shell
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// completerFunc adapts a plain function to the readline.AutoCompleter
+// interface.
+type completerFunc func(line []rune, pos int) (newLine [][]rune, length int)
+
+// Do implements readline.AutoCompleter.
+func (f completerFunc) Do(line []rune, pos int) ([][]rune, int) {
+	return f(line, pos)
+}
+
+// complete drives tab completion from the root cobra command's own
+// subcommand names, flag names, and — for a "--flag=" word being
+// completed — the flag's own registered completion function (the same
+// completion surface used by "sq ... --flag=<TAB>" on the command
+// line, e.g. completeStrings/completeBool as registered via
+// RegisterFlagCompletionFunc in addTimeFormatOptsFlags).
+func (sh *Shell) complete(line []rune, pos int) (newLine [][]rune, length int) {
+	toComplete := string(line[:pos])
+	fields := strings.Fields(toComplete)
+
+	var word string
+	if len(fields) > 0 && !strings.HasSuffix(toComplete, " ") {
+		word = fields[len(fields)-1]
+		fields = fields[:len(fields)-1]
+	}
+
+	target, _, err := sh.root.Find(fields)
+	if err != nil || target == nil {
+		target = sh.root
+	}
+
+	if strings.HasPrefix(word, "--") {
+		if name, valPrefix, ok := strings.Cut(word[2:], "="); ok {
+			return sh.completeFlagValue(target, fields, name, valPrefix)
+		}
+	}
+
+	var candidates []string
+	if strings.HasPrefix(word, "--") {
+		target.Flags().VisitAll(func(f *pflag.Flag) {
+			candidates = append(candidates, "--"+f.Name)
+		})
+	} else {
+		for _, c := range target.Commands() {
+			candidates = append(candidates, c.Name())
+		}
+	}
+
+	runes := make([][]rune, 0, len(candidates))
+	for _, c := range candidates {
+		if strings.HasPrefix(c, word) {
+			runes = append(runes, []rune(c[len(word):]))
+		}
+	}
+
+	return runes, len(word)
+}
+
+// completeFlagValue completes the value half of a "--name=value" word
+// by invoking name's registered flag completion function (see
+// cobra.Command.RegisterFlagCompletionFunc), the same function cobra's
+// own "__complete" machinery would call for shell completion on the
+// command line. Returns nil if name isn't a known flag on target, or
+// has no registered completion function.
+func (sh *Shell) completeFlagValue(target *cobra.Command, args []string, name, valPrefix string) ([][]rune, int) {
+	flag := target.Flags().Lookup(name)
+	if flag == nil {
+		return nil, 0
+	}
+
+	fn, ok := cobra.GetFlagCompletionFunc(flag)
+	if !ok {
+		return nil, 0
+	}
+
+	values, _ := fn(target, args, valPrefix)
+
+	runes := make([][]rune, 0, len(values))
+	for _, v := range values {
+		// cobra completion values may carry a "\tdescription" suffix.
+		v, _, _ = strings.Cut(v, "\t")
+		if strings.HasPrefix(v, valPrefix) {
+			runes = append(runes, []rune(v[len(valPrefix):]))
+		}
+	}
+
+	return runes, len(valPrefix)
+}
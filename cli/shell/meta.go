@@ -0,0 +1,100 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/neilotoole/sq/libsq/core/errz"
+)
+
+// execMeta handles a single "\"-prefixed meta-command, e.g. "\d @sakila",
+// "\src @sakila", "\set format=jsonl", or "\q". It returns quit=true when
+// the shell should exit, in which case err (if any) should be returned
+// from Run.
+func (sh *Shell) execMeta(ctx context.Context, line string) (quit bool, err error) {
+	fields, err := tokenize(line)
+	if err != nil {
+		return false, err
+	}
+	cmd, args := fields[0], fields[1:]
+
+	switch cmd {
+	case `\q`:
+		return true, nil
+	case `\d`:
+		return false, sh.metaDescribe(ctx, args)
+	case `\src`:
+		return false, sh.metaSrc(ctx, args)
+	case `\set`:
+		return false, sh.metaSet(args)
+	default:
+		return false, errz.Errorf("shell: unrecognized meta-command: %s", cmd)
+	}
+}
+
+// metaDescribe implements "\d [@handle]", describing the named source
+// (or the active source if handle is omitted) by dispatching to the
+// existing "sq inspect" command.
+func (sh *Shell) metaDescribe(ctx context.Context, args []string) error {
+	cmdArgs := append([]string{"inspect"}, args...)
+	sh.root.SetArgs(cmdArgs)
+	return errz.Err(sh.root.ExecuteContext(ctx))
+}
+
+// metaSrc implements "\src [@handle]": with no argument it prints the
+// active source, and with a handle it switches the active source by
+// dispatching to "sq src".
+func (sh *Shell) metaSrc(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		sh.root.SetArgs([]string{"src"})
+		return errz.Err(sh.root.ExecuteContext(ctx))
+	}
+
+	sh.root.SetArgs([]string{"src", args[0]})
+	if err := sh.root.ExecuteContext(ctx); err != nil {
+		return err
+	}
+
+	sh.activeSrc = args[0]
+	return nil
+}
+
+// metaSet implements "\set key=val", setting an option for the remainder
+// of the shell session: key must be registered in the registry, and val
+// is passed as "--key=val" to every later statement (see sessionFlags).
+// With no argument, it prints all registered options and their current
+// session values: the value set via \set, else the option's default.
+func (sh *Shell) metaSet(args []string) error {
+	if len(args) == 0 {
+		for _, opt := range sh.reg.Opts() {
+			var val any = opt.GetAny(nil)
+			if v, ok := sh.sessionFlags[opt.Key()]; ok {
+				val = v
+			}
+			fmt.Fprintf(sh.out, "%s = %v\n", opt.Key(), val)
+		}
+		return nil
+	}
+
+	kv := strings.SplitN(args[0], "=", 2)
+	if len(kv) != 2 {
+		return errz.Errorf(`shell: invalid \set syntax, expected key=val, got: %s`, args[0])
+	}
+
+	key, val := kv[0], kv[1]
+
+	var known bool
+	for _, opt := range sh.reg.Opts() {
+		if opt.Key() == key {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return errz.Errorf("shell: unknown option: %s", key)
+	}
+
+	sh.sessionFlags[key] = val
+	return nil
+}
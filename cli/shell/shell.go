@@ -0,0 +1,214 @@
+// Package shell implements an interactive REPL for sq, analogous to the
+// psql/mysql clients. It reuses the host process's cobra command tree: each
+// line of input is tokenized and dispatched to the root command, so every
+// flag, option, and completion function that works on the command line
+// works identically inside the shell.
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chzyer/readline"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/neilotoole/sq/cli/output"
+	"github.com/neilotoole/sq/libsq/core/errz"
+	"github.com/neilotoole/sq/libsq/core/options"
+)
+
+// historyFile is the path (relative to the user's config dir) of the
+// persisted shell history, e.g. ~/.config/sq/history.
+const historyFile = "history"
+
+// continuation is the prompt shown for a line continuing a multi-line
+// statement that has not yet been terminated by ";".
+const continuation = "... "
+
+// Shell is an interactive REPL that dispatches input to root, sq's
+// top-level cobra command.
+type Shell struct {
+	root   *cobra.Command
+	reg    *options.Registry
+	pr     *output.Printing
+	out    io.Writer
+	errOut io.Writer
+	rl     *readline.Instance
+
+	// activeSrc is the handle of the source the shell believes is active,
+	// e.g. "@sakila". It is updated by the \src meta-command and is used
+	// only to render the prompt.
+	activeSrc string
+
+	// sessionFlags holds option values set live via \set for the
+	// remainder of the session. Each entry is applied as a "--key=val"
+	// flag to every subsequently dispatched command.
+	sessionFlags map[string]string
+}
+
+// New returns a Shell that dispatches input against root, writing
+// normal output to out and error output to errOut (so that, e.g.,
+// piping a "sq shell" session's stdout into jq or vector isn't
+// corrupted by interleaved error text). The returned Shell must be
+// closed via Close when the caller is done with it.
+func New(root *cobra.Command, reg *options.Registry, pr *output.Printing, out, errOut io.Writer) (*Shell, error) {
+	histPath, err := historyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          "", // set per-line by promptLine
+		HistoryFile:     histPath,
+		InterruptPrompt: "^C",
+		EOFPrompt:       "\\q",
+		Stdout:          out,
+		Stderr:          errOut,
+		AutoComplete:    nil, // set below, once sh exists
+	})
+	if err != nil {
+		return nil, errz.Wrap(err, "shell: init readline")
+	}
+
+	sh := &Shell{
+		root: root, reg: reg, pr: pr, out: out, errOut: errOut, rl: rl,
+		sessionFlags: map[string]string{},
+	}
+	rl.Config.AutoComplete = completerFunc(sh.complete)
+	return sh, nil
+}
+
+// Close releases the shell's resources, flushing history to disk.
+func (sh *Shell) Close() error {
+	return errz.Err(sh.rl.Close())
+}
+
+// Run reads statements and meta-commands from stdin until the user quits
+// (via \q, Ctrl-D, or ctx being done), dispatching each to sh.root.
+func (sh *Shell) Run(ctx context.Context) error {
+	var buf strings.Builder
+
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		sh.rl.SetPrompt(sh.prompt(buf.Len() > 0))
+		line, err := sh.rl.Readline()
+		switch {
+		case err == readline.ErrInterrupt:
+			buf.Reset()
+			continue
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return errz.Err(err)
+		}
+
+		line = strings.TrimSpace(line)
+		if buf.Len() == 0 && strings.HasPrefix(line, `\`) {
+			if quit, err := sh.execMeta(ctx, line); quit {
+				return err
+			} else if err != nil {
+				fmt.Fprintln(sh.errOut, err)
+			}
+			continue
+		}
+
+		if buf.Len() > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(line)
+
+		if !strings.HasSuffix(strings.TrimSpace(buf.String()), ";") {
+			continue
+		}
+
+		stmt := strings.TrimSuffix(strings.TrimSpace(buf.String()), ";")
+		buf.Reset()
+		if stmt == "" {
+			continue
+		}
+
+		if err = sh.dispatch(ctx, stmt); err != nil {
+			fmt.Fprintln(sh.errOut, sh.pr.Error.Sprint(err))
+		}
+	}
+}
+
+// dispatch tokenizes line and executes it against sh.root, the way the
+// shell of a normal sq process would. sh.root's flag state is reset
+// afterwards, since pflag.FlagSet.Changed never clears itself between
+// Parse calls on a reused FlagSet: without this, a flag set on one
+// statement would keep being read (as "changed", with its stale value)
+// by getOptionsFromFlags on every later statement in the session.
+func (sh *Shell) dispatch(ctx context.Context, line string) error {
+	args, err := tokenize(line)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	for key, val := range sh.sessionFlags {
+		args = append(args, fmt.Sprintf("--%s=%s", key, val))
+	}
+
+	sh.root.SetArgs(args)
+	err = sh.root.ExecuteContext(ctx)
+	resetFlags(sh.root)
+	return err
+}
+
+// resetFlags recursively restores every flag under cmd (and its
+// subcommands) to its default value and clears pflag's Changed marker,
+// so the next dispatched statement starts from a clean flag state.
+func resetFlags(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		_ = f.Value.Set(f.DefValue)
+		f.Changed = false
+	})
+
+	for _, c := range cmd.Commands() {
+		resetFlags(c)
+	}
+}
+
+// prompt returns the colored shell prompt, using Printing.Active for the
+// "sq" program name and Printing.Handle for the active source handle.
+func (sh *Shell) prompt(continued bool) string {
+	if continued {
+		return continuation
+	}
+
+	name := sh.pr.Active.Sprint("sq")
+	if sh.activeSrc == "" {
+		return name + "> "
+	}
+	return name + " " + sh.pr.Handle.Sprint(sh.activeSrc) + "> "
+}
+
+// historyPath returns the path to the persisted shell history file,
+// creating its parent directory if necessary.
+func historyPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", errz.Wrap(err, "shell: resolve config dir")
+	}
+
+	dir = filepath.Join(dir, "sq")
+	if err = os.MkdirAll(dir, 0o750); err != nil {
+		return "", errz.Wrap(err, "shell: create config dir")
+	}
+
+	return filepath.Join(dir, historyFile), nil
+}
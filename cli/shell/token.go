@@ -0,0 +1,73 @@
+package shell
+
+import (
+	"strings"
+
+	"github.com/neilotoole/sq/libsq/core/errz"
+)
+
+// tokenize splits line into shell-style fields, the way a POSIX shell
+// would: whitespace separates fields, and single or double quotes group
+// whitespace into a single field (the quotes themselves are stripped).
+// Within double quotes, \" and \\ are recognized as escapes; within
+// single quotes, no escapes are recognized. Unlike strings.Fields, this
+// lets a SLQ statement or meta-command argument carry a quoted string
+// literal containing spaces, e.g.:
+//
+//	.actor | where(.first_name=="TOM HANKS")
+func tokenize(line string) ([]string, error) {
+	var fields []string
+	var cur strings.Builder
+	var inField bool
+
+	flush := func() {
+		if inField {
+			fields = append(fields, cur.String())
+			cur.Reset()
+			inField = false
+		}
+	}
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == ' ' || r == '\t':
+			flush()
+		case r == '\'':
+			inField = true
+			j := i + 1
+			for j < len(runes) && runes[j] != '\'' {
+				cur.WriteRune(runes[j])
+				j++
+			}
+			if j >= len(runes) {
+				return nil, errz.Errorf("shell: unterminated ' quote")
+			}
+			i = j
+		case r == '"':
+			inField = true
+			j := i + 1
+			for j < len(runes) && runes[j] != '"' {
+				if runes[j] == '\\' && j+1 < len(runes) && (runes[j+1] == '"' || runes[j+1] == '\\') {
+					cur.WriteRune(runes[j+1])
+					j += 2
+					continue
+				}
+				cur.WriteRune(runes[j])
+				j++
+			}
+			if j >= len(runes) {
+				return nil, errz.Errorf(`shell: unterminated " quote`)
+			}
+			i = j
+		default:
+			inField = true
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+
+	return fields, nil
+}
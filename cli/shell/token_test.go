@@ -0,0 +1,67 @@
+package shell
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{name: "empty", in: "", want: nil},
+		{name: "simple", in: "inspect @sakila", want: []string{"inspect", "@sakila"}},
+		{
+			name: "double-quoted string with space",
+			in:   `.actor | where(.first_name=="TOM HANKS")`,
+			want: []string{`.actor`, `|`, `where(.first_name==TOM HANKS)`},
+		},
+		{
+			name: "double-quoted field",
+			in:   `\set key="a value"`,
+			want: []string{`\set`, `key=a value`},
+		},
+		{
+			name: "single-quoted field",
+			in:   `\set key='a value'`,
+			want: []string{`\set`, `key=a value`},
+		},
+		{
+			name: "escaped quote inside double quotes",
+			in:   `\set key="say \"hi\""`,
+			want: []string{`\set`, `key=say "hi"`},
+		},
+		{
+			name: "extra whitespace collapses",
+			in:   "  a   b  ",
+			want: []string{"a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tokenize(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokenize(%q) = %#v, want %#v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenize_unterminatedQuote(t *testing.T) {
+	tests := []string{
+		`\set key="unterminated`,
+		`\set key='unterminated`,
+	}
+
+	for _, in := range tests {
+		if _, err := tokenize(in); err == nil {
+			t.Errorf("tokenize(%q): expected error for unterminated quote, got nil", in)
+		}
+	}
+}
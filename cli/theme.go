@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/neilotoole/sq/cli/output"
+	"github.com/neilotoole/sq/cli/output/theme"
+	"github.com/neilotoole/sq/libsq/core/errz"
+	"github.com/neilotoole/sq/libsq/core/options"
+)
+
+// OptTheme specifies the color theme applied to CLI output. In addition
+// to the built-in presets (see theme.Names), a user theme may be loaded
+// from ~/.config/sq/themes/<name>.yml.
+var OptTheme = options.NewString(
+	"theme",
+	"",
+	0,
+	"default",
+	nil,
+	"Color theme",
+	`Specify the color theme, one of the built-in presets (default, dracula,
+monokai, nord, solarized-dark, solarized-light) or the name of a user
+theme defined in ~/.config/sq/themes/<name>.yml.`,
+)
+
+// applyTheme loads the theme named by OptTheme from opts and applies it
+// to pr.
+func applyTheme(pr *output.Printing, opts options.Options) error {
+	th, err := theme.Load(OptTheme.Get(opts))
+	if err != nil {
+		return errz.Wrap(err, "load theme")
+	}
+
+	return pr.SetTheme(th)
+}
+
+// addThemeOptFlag adds the --theme flag to cmd, with shell completion
+// listing the built-in preset names.
+func addThemeOptFlag(cmd *cobra.Command) {
+	key := addOptionFlag(cmd.Flags(), OptTheme)
+	panicOn(cmd.RegisterFlagCompletionFunc(key, completeStrings(-1, theme.Names()...)))
+}